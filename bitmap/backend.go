@@ -0,0 +1,27 @@
+package bitmap
+
+// Backend is the common contract every bitmap storage implementation satisfies: Bitmap, Redis,
+// RedisCounter, MemoryCounter, Badger, and Memcached. Filter implementations hold a Backend so
+// they don't need to care which storage backs the bits they test and set.
+//
+// There is no factory or config package in this tree, so no config type selects a backend:
+// callers construct one directly (NewBitmap, NewRedis, NewBadger, NewMemcached, ...).
+type Backend interface {
+	// CheckBits reports whether every location in locs is set.
+	CheckBits(locs []uint64) (bool, error)
+	// SetBits sets every location in locs.
+	SetBits(locs []uint64) error
+	// Close releases any resources the backend holds for this bitmap (e.g. its key/value).
+	Close() error
+	// Reset clears the bitmap back to its zero value without releasing the backend's resources.
+	Reset() error
+}
+
+// AtomicBackend is implemented by backends that can perform CheckBits+SetBits as a single
+// atomic operation. It's optional: not every storage can offer it (Memcached's item-level CAS
+// doesn't span multiple keys), so callers type-assert for it rather than requiring it of every
+// Backend.
+type AtomicBackend interface {
+	Backend
+	CheckAndSetBits(locs []uint64) (existed bool, err error)
+}
@@ -0,0 +1,156 @@
+package bitmap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Badger is a bitmap backend stored as a single key in an embedded BadgerDB, whose value is the
+// raw m-bit array. CheckBits/SetBits address it one 64-bit word at a time; each SetBits (and
+// CheckAndSetBits) transaction reads the whole value, updates it, and writes the whole value back.
+type Badger struct {
+	db  *badger.DB
+	key []byte
+	m   uint64
+}
+
+// bitWord returns the index of the 64-bit word containing bit loc, and the bit's offset within it.
+func bitWord(loc uint64) (word int, bit uint) {
+	return int(loc / 64), uint(loc % 64)
+}
+
+func (b *Badger) CheckBits(locs []uint64) (bool, error) {
+	exist := true
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(b.key)
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			for _, loc := range locs {
+				word, bit := bitWord(loc % b.m)
+				w := binary.LittleEndian.Uint64(val[word*8 : word*8+8])
+				if w&(1<<bit) == 0 {
+					exist = false
+					return nil
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return exist, nil
+}
+
+func (b *Badger) SetBits(locs []uint64) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get(b.key)
+		if err != nil {
+			return err
+		}
+		var val []byte
+		if err := item.Value(func(v []byte) error {
+			val = append(val, v...)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, loc := range locs {
+			word, bit := bitWord(loc % b.m)
+			w := binary.LittleEndian.Uint64(val[word*8 : word*8+8])
+			w |= 1 << bit
+			binary.LittleEndian.PutUint64(val[word*8:word*8+8], w)
+		}
+		return txn.Set(b.key, val)
+	})
+}
+
+// CheckAndSetBits atomically checks whether every location in locs was already set and then
+// sets them all to 1, all within a single Badger transaction.
+func (b *Badger) CheckAndSetBits(locs []uint64) (existed bool, err error) {
+	existed = true
+	err = b.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get(b.key)
+		if err != nil {
+			return err
+		}
+		var val []byte
+		if err := item.Value(func(v []byte) error {
+			val = append(val, v...)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, loc := range locs {
+			word, bit := bitWord(loc % b.m)
+			w := binary.LittleEndian.Uint64(val[word*8 : word*8+8])
+			if w&(1<<bit) == 0 {
+				existed = false
+			}
+			w |= 1 << bit
+			binary.LittleEndian.PutUint64(val[word*8:word*8+8], w)
+		}
+		return txn.Set(b.key, val)
+	})
+	if err != nil {
+		return false, err
+	}
+	return existed, nil
+}
+
+// Close deletes the bitmap's key from Badger, releasing the space it held.
+func (b *Badger) Close() error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(b.key)
+	})
+}
+
+// Reset clears every bit back to 0 without changing the bitmap's key.
+func (b *Badger) Reset() error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(b.key, make([]byte, wordAlignedBytes(b.m)))
+	})
+}
+
+// wordAlignedBytes returns how many bytes an m-bit array needs when stored as whole 64-bit words.
+func wordAlignedBytes(m uint64) uint64 {
+	words := (m + 63) / 64
+	return words * 8
+}
+
+// NewBadger returns a bitmap of m bits stored under key in db. If the key doesn't already exist
+// it's initialized to all zeros.
+func NewBadger(db *badger.DB, key string, m uint64) (*Badger, error) {
+	b := &Badger{
+		db:  db,
+		key: []byte(key),
+		m:   m,
+	}
+
+	err := db.Update(func(txn *badger.Txn) error {
+		_, err := txn.Get(b.key)
+		switch err {
+		case nil:
+			return nil
+		case badger.ErrKeyNotFound:
+			return txn.Set(b.key, make([]byte, wordAlignedBytes(m)))
+		default:
+			return err
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bitmap: NewBadger: %w", err)
+	}
+
+	return b, nil
+}
+
+var (
+	_ Backend       = (*Badger)(nil)
+	_ AtomicBackend = (*Badger)(nil)
+)
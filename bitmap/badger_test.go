@@ -0,0 +1,42 @@
+package bitmap
+
+import "testing"
+
+func TestBitWord(t *testing.T) {
+	cases := []struct {
+		loc      uint64
+		wantWord int
+		wantBit  uint
+	}{
+		{loc: 0, wantWord: 0, wantBit: 0},
+		{loc: 63, wantWord: 0, wantBit: 63},
+		{loc: 64, wantWord: 1, wantBit: 0},
+		{loc: 200, wantWord: 3, wantBit: 8},
+	}
+
+	for _, c := range cases {
+		word, bit := bitWord(c.loc)
+		if word != c.wantWord || bit != c.wantBit {
+			t.Errorf("bitWord(%d) = (%d, %d), want (%d, %d)", c.loc, word, bit, c.wantWord, c.wantBit)
+		}
+	}
+}
+
+func TestWordAlignedBytes(t *testing.T) {
+	cases := []struct {
+		m    uint64
+		want uint64
+	}{
+		{m: 0, want: 0},
+		{m: 1, want: 8},
+		{m: 64, want: 8},
+		{m: 65, want: 16},
+		{m: 128, want: 16},
+	}
+
+	for _, c := range cases {
+		if got := wordAlignedBytes(c.m); got != c.want {
+			t.Errorf("wordAlignedBytes(%d) = %d, want %d", c.m, got, c.want)
+		}
+	}
+}
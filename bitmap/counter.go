@@ -0,0 +1,187 @@
+package bitmap
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"sync"
+	"time"
+)
+
+// CounterMax is the highest value a single counter slot can hold. Both RedisCounter (a u8
+// BITFIELD) and MemoryCounter (a []uint8) saturate at this value instead of overflowing, so a
+// very hot slot stays at CounterMax rather than wrapping back to 0. Increments past CounterMax
+// are not counted, and DecBits decrements a saturated slot like any other.
+const CounterMax = 255
+
+// RedisCounter is a counting-bloom bitmap backed by Redis BITFIELD, where every slot is an
+// 8-bit counter instead of a single bit. It lets filter.Counting implement Remove by
+// decrementing a slot's counter instead of swapping the whole filter.
+type RedisCounter struct {
+	ctx    context.Context
+	client redis.UniversalClient
+	key    string
+	m      uint64
+}
+
+func (r *RedisCounter) CheckBits(locs []uint64) (bool, error) {
+	pl := r.client.Pipeline()
+
+	var results []*redis.IntSliceCmd
+	for _, loc := range locs {
+		results = append(results, pl.BitField(r.ctx, r.key, "GET", "u8", fmt.Sprintf("#%d", loc%r.m)))
+	}
+	_, err := pl.Exec(r.ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range results {
+		res, err := v.Result()
+		if err != nil {
+			return false, err
+		}
+		if len(res) == 0 || res[0] == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SetBits increments the counter at every location in locs by 1, saturating at CounterMax.
+func (r *RedisCounter) SetBits(locs []uint64) error {
+	pl := r.client.Pipeline()
+	for _, loc := range locs {
+		offset := fmt.Sprintf("#%d", loc%r.m)
+		pl.BitField(r.ctx, r.key, "OVERFLOW", "SAT", "INCRBY", "u8", offset, 1)
+	}
+	_, err := pl.Exec(r.ctx)
+	return err
+}
+
+// DecBits decrements the counter at every location in locs by 1, floored at 0.
+func (r *RedisCounter) DecBits(locs []uint64) error {
+	pl := r.client.Pipeline()
+	for _, loc := range locs {
+		offset := fmt.Sprintf("#%d", loc%r.m)
+		pl.BitField(r.ctx, r.key, "OVERFLOW", "SAT", "INCRBY", "u8", offset, -1)
+	}
+	_, err := pl.Exec(r.ctx)
+	return err
+}
+
+// M returns the number of counter slots, so callers can pick locations to decrement at random
+// (e.g. filter.Counting.DecrementRandom) without knowing the backend's internal layout.
+func (r *RedisCounter) M() uint64 {
+	return r.m
+}
+
+// Close deletes the counter bitmap's key from Redis, releasing the space it held.
+func (r *RedisCounter) Close() error {
+	return r.client.Del(r.ctx, r.key).Err()
+}
+
+// Reset clears every counter back to 0 without changing the bitmap's key.
+func (r *RedisCounter) Reset() error {
+	if err := r.client.Del(r.ctx, r.key).Err(); err != nil {
+		return err
+	}
+	return r.client.BitField(r.ctx, r.key, "SET", "u8", "#0", 0).Err()
+}
+
+// NewRedisCounter returns a counting bitmap stored in Redis and manipulated via BITFIELD.
+func NewRedisCounter(ctx context.Context, client redis.UniversalClient, key string, m uint64) (*RedisCounter, error) {
+	k := fmt.Sprintf("%s_%d", key, time.Now().UnixNano())
+
+	r := &RedisCounter{
+		ctx:    ctx,
+		client: client,
+		key:    k,
+		m:      m,
+	}
+	// Touch the key so it exists even before the first Add, mirroring NewRedisUniversal.
+	r.client.BitField(r.ctx, r.key, "SET", "u8", "#0", 0)
+
+	return r, nil
+}
+
+// MemoryCounter is a counting-bloom bitmap held in process memory as one byte per slot.
+type MemoryCounter struct {
+	mu   sync.Mutex
+	bits []uint8
+	m    uint64
+}
+
+func (c *MemoryCounter) CheckBits(locs []uint64) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, loc := range locs {
+		if c.bits[loc%c.m] == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SetBits increments the counter at every location in locs by 1, saturating at CounterMax.
+func (c *MemoryCounter) SetBits(locs []uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, loc := range locs {
+		i := loc % c.m
+		if c.bits[i] < CounterMax {
+			c.bits[i]++
+		}
+	}
+	return nil
+}
+
+// DecBits decrements the counter at every location in locs by 1, floored at 0.
+func (c *MemoryCounter) DecBits(locs []uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, loc := range locs {
+		i := loc % c.m
+		if c.bits[i] > 0 {
+			c.bits[i]--
+		}
+	}
+	return nil
+}
+
+// M returns the number of counter slots, so callers can pick locations to decrement at random
+// (e.g. filter.Counting.DecrementRandom) without knowing the backend's internal layout.
+func (c *MemoryCounter) M() uint64 {
+	return c.m
+}
+
+// Close is a no-op: a MemoryCounter holds no resources beyond its own backing slice.
+func (c *MemoryCounter) Close() error {
+	return nil
+}
+
+// Reset clears every counter back to 0.
+func (c *MemoryCounter) Reset() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.bits {
+		c.bits[i] = 0
+	}
+	return nil
+}
+
+// NewMemoryCounter returns a counting bitmap of m slots held in process memory.
+func NewMemoryCounter(m uint64) (*MemoryCounter, error) {
+	return &MemoryCounter{
+		bits: make([]uint8, m),
+		m:    m,
+	}, nil
+}
+
+var (
+	_ Backend = (*RedisCounter)(nil)
+	_ Backend = (*MemoryCounter)(nil)
+)
@@ -0,0 +1,72 @@
+package bitmap
+
+import "testing"
+
+func TestMemoryCounter(t *testing.T) {
+	c, err := NewMemoryCounter(100)
+	if err != nil {
+		t.Fatalf("NewMemoryCounter() error = %v", err)
+	}
+
+	locs := []uint64{1, 2, 3}
+
+	if exist, _ := c.CheckBits(locs); exist {
+		t.Fatalf("CheckBits() = true before any SetBits")
+	}
+
+	if err := c.SetBits(locs); err != nil {
+		t.Fatalf("SetBits() error = %v", err)
+	}
+	if exist, err := c.CheckBits(locs); err != nil || !exist {
+		t.Fatalf("CheckBits() = %v, %v, want true, nil", exist, err)
+	}
+
+	if err := c.DecBits(locs); err != nil {
+		t.Fatalf("DecBits() error = %v", err)
+	}
+	if exist, _ := c.CheckBits(locs); exist {
+		t.Fatalf("CheckBits() = true after decrementing back to 0")
+	}
+
+	// DecBits floors at 0 instead of wrapping.
+	if err := c.DecBits(locs); err != nil {
+		t.Fatalf("DecBits() on an already-zero counter error = %v", err)
+	}
+	if exist, _ := c.CheckBits(locs); exist {
+		t.Fatalf("CheckBits() = true after decrementing below 0")
+	}
+}
+
+func TestMemoryCounterSaturatesAtMax(t *testing.T) {
+	c, err := NewMemoryCounter(10)
+	if err != nil {
+		t.Fatalf("NewMemoryCounter() error = %v", err)
+	}
+
+	loc := []uint64{0}
+	for i := 0; i < int(CounterMax)+10; i++ {
+		if err := c.SetBits(loc); err != nil {
+			t.Fatalf("SetBits() error = %v", err)
+		}
+	}
+	if c.bits[0] != CounterMax {
+		t.Fatalf("counter = %d, want saturated at %d", c.bits[0], CounterMax)
+	}
+}
+
+func TestMemoryCounterReset(t *testing.T) {
+	c, err := NewMemoryCounter(10)
+	if err != nil {
+		t.Fatalf("NewMemoryCounter() error = %v", err)
+	}
+
+	if err := c.SetBits([]uint64{0, 5}); err != nil {
+		t.Fatalf("SetBits() error = %v", err)
+	}
+	if err := c.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if exist, _ := c.CheckBits([]uint64{0, 5}); exist {
+		t.Fatalf("CheckBits() = true after Reset")
+	}
+}
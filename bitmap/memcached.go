@@ -0,0 +1,151 @@
+package bitmap
+
+import (
+	"fmt"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// memcachedChunkBytes is the size of each chunk Memcached splits the bitmap into. Memcached
+// rejects items over 1MB, so a large m is sharded across "key:chunk_index" items instead of
+// living in one value.
+const memcachedChunkBytes = 8 * 1024
+const memcachedChunkBits = memcachedChunkBytes * 8
+
+// memcachedCASRetries bounds how many times SetBits retries a chunk's
+// Get+CompareAndSwap before giving up to a concurrent writer.
+const memcachedCASRetries = 10
+
+// Memcached is a bitmap backend stored in Memcached as a series of fixed-size chunks, each
+// updated via CAS so concurrent SetBits calls on the same chunk don't clobber each other.
+type Memcached struct {
+	client *memcache.Client
+	key    string
+	m      uint64
+}
+
+func (bm *Memcached) chunkKey(chunk uint64) string {
+	return fmt.Sprintf("%s:%d", bm.key, chunk)
+}
+
+// splitLoc reduces loc into the bitmap's range, then splits it into a chunk index and the bit's
+// offset within that chunk.
+func (bm *Memcached) splitLoc(loc uint64) (chunk uint64, bit uint) {
+	loc %= bm.m
+	return loc / memcachedChunkBits, uint(loc % memcachedChunkBits)
+}
+
+// getChunk fetches chunk's item, creating it (all zeros) first if it doesn't exist yet.
+func (bm *Memcached) getChunk(chunk uint64) (*memcache.Item, error) {
+	item, err := bm.client.Get(bm.chunkKey(chunk))
+	if err == memcache.ErrCacheMiss {
+		newItem := &memcache.Item{
+			Key:   bm.chunkKey(chunk),
+			Value: make([]byte, memcachedChunkBytes),
+		}
+		if err := bm.client.Add(newItem); err != nil && err != memcache.ErrNotStored {
+			return nil, err
+		}
+		return bm.client.Get(bm.chunkKey(chunk))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (bm *Memcached) CheckBits(locs []uint64) (bool, error) {
+	byChunk := make(map[uint64][]uint)
+	for _, loc := range locs {
+		chunk, bit := bm.splitLoc(loc)
+		byChunk[chunk] = append(byChunk[chunk], bit)
+	}
+
+	for chunk, bits := range byChunk {
+		item, err := bm.getChunk(chunk)
+		if err != nil {
+			return false, err
+		}
+		for _, bit := range bits {
+			if item.Value[bit/8]&(1<<(bit%8)) == 0 {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func (bm *Memcached) SetBits(locs []uint64) error {
+	byChunk := make(map[uint64][]uint)
+	for _, loc := range locs {
+		chunk, bit := bm.splitLoc(loc)
+		byChunk[chunk] = append(byChunk[chunk], bit)
+	}
+
+	for chunk, bits := range byChunk {
+		if err := bm.setChunkBits(chunk, bits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setChunkBits sets bits within chunk via a Get+CompareAndSwap loop, retrying when a concurrent
+// writer updates the same chunk between the Get and the CAS.
+func (bm *Memcached) setChunkBits(chunk uint64, bits []uint) error {
+	for attempt := 0; attempt < memcachedCASRetries; attempt++ {
+		item, err := bm.getChunk(chunk)
+		if err != nil {
+			return err
+		}
+
+		for _, bit := range bits {
+			item.Value[bit/8] |= 1 << (bit % 8)
+		}
+
+		err = bm.client.CompareAndSwap(item)
+		if err == nil {
+			return nil
+		}
+		if err != memcache.ErrCASConflict {
+			return err
+		}
+	}
+	return fmt.Errorf("bitmap: Memcached.SetBits: too many CAS conflicts on chunk %d", chunk)
+}
+
+// Close deletes every chunk this bitmap owns from Memcached.
+func (bm *Memcached) Close() error {
+	chunks := (bm.m + memcachedChunkBits - 1) / memcachedChunkBits
+	for i := uint64(0); i < chunks; i++ {
+		if err := bm.client.Delete(bm.chunkKey(i)); err != nil && err != memcache.ErrCacheMiss {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset clears every bit in every chunk back to 0.
+func (bm *Memcached) Reset() error {
+	chunks := (bm.m + memcachedChunkBits - 1) / memcachedChunkBits
+	for i := uint64(0); i < chunks; i++ {
+		if err := bm.client.Set(&memcache.Item{
+			Key:   bm.chunkKey(i),
+			Value: make([]byte, memcachedChunkBytes),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewMemcached returns a bitmap of m bits sharded across Memcached items of memcachedChunkBytes
+// each, keyed as "key:chunk_index".
+func NewMemcached(client *memcache.Client, key string, m uint64) (*Memcached, error) {
+	return &Memcached{
+		client: client,
+		key:    key,
+		m:      m,
+	}, nil
+}
+
+var _ Backend = (*Memcached)(nil)
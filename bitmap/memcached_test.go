@@ -0,0 +1,37 @@
+package bitmap
+
+import "testing"
+
+func TestMemcachedChunkKey(t *testing.T) {
+	bm := &Memcached{key: "myfilter"}
+	if got, want := bm.chunkKey(0), "myfilter:0"; got != want {
+		t.Errorf("chunkKey(0) = %q, want %q", got, want)
+	}
+	if got, want := bm.chunkKey(3), "myfilter:3"; got != want {
+		t.Errorf("chunkKey(3) = %q, want %q", got, want)
+	}
+}
+
+func TestMemcachedSplitLoc(t *testing.T) {
+	bm := &Memcached{m: memcachedChunkBits * 3}
+
+	cases := []struct {
+		loc       uint64
+		wantChunk uint64
+		wantBit   uint
+	}{
+		{loc: 0, wantChunk: 0, wantBit: 0},
+		{loc: memcachedChunkBits - 1, wantChunk: 0, wantBit: memcachedChunkBits - 1},
+		{loc: memcachedChunkBits, wantChunk: 1, wantBit: 0},
+		{loc: memcachedChunkBits + 5, wantChunk: 1, wantBit: 5},
+		// wraps around bm.m before splitting into a chunk.
+		{loc: memcachedChunkBits*3 + 1, wantChunk: 0, wantBit: 1},
+	}
+
+	for _, c := range cases {
+		chunk, bit := bm.splitLoc(c.loc)
+		if chunk != c.wantChunk || bit != c.wantBit {
+			t.Errorf("splitLoc(%d) = (%d, %d), want (%d, %d)", c.loc, chunk, bit, c.wantChunk, c.wantBit)
+		}
+	}
+}
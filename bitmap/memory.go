@@ -0,0 +1,145 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sync"
+)
+
+// bitmapMagic identifies a Bitmap snapshot produced by MarshalBinary, so UnmarshalBinary can
+// reject unrelated data instead of misinterpreting it.
+var bitmapMagic = [4]byte{'B', 'F', 'B', 'M'}
+
+// bitmapVersion is bumped whenever MarshalBinary's layout changes incompatibly.
+const bitmapVersion = 1
+
+// bitmapHeaderLen is magic(4) + version(1) + m(8) + k(8) + hashFamily(1).
+const bitmapHeaderLen = 4 + 1 + 8 + 8 + 1
+
+// Bitmap is a bitmap backend held in process memory as a packed byte slice. It's the in-memory
+// counterpart to Redis, and the one backend that knows how to serialize itself for Rotator's
+// snapshot/warm-start support (see filter.Bloom.Snapshot/LoadSnapshot).
+type Bitmap struct {
+	mu         sync.Mutex
+	bits       []byte
+	m          uint64
+	k          uint64
+	hashFamily uint8
+}
+
+// NewBitmap returns a bitmap of m bits. k and hashFamily are carried along purely as metadata
+// for MarshalBinary/UnmarshalBinary, so a restored snapshot can be handed back to a filter with
+// the same hash parameters it was built with.
+func NewBitmap(m, k uint64, hashFamily uint8) *Bitmap {
+	return &Bitmap{
+		bits:       make([]byte, (m+7)/8),
+		m:          m,
+		k:          k,
+		hashFamily: hashFamily,
+	}
+}
+
+func (b *Bitmap) CheckBits(locs []uint64) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, loc := range locs {
+		i := loc % b.m
+		if b.bits[i/8]&(1<<(i%8)) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (b *Bitmap) SetBits(locs []uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, loc := range locs {
+		i := loc % b.m
+		b.bits[i/8] |= 1 << (i % 8)
+	}
+	return nil
+}
+
+// Close is a no-op: a Bitmap holds no resources beyond its own backing slice.
+func (b *Bitmap) Close() error {
+	return nil
+}
+
+// Reset clears every bit back to 0.
+func (b *Bitmap) Reset() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+	return nil
+}
+
+// M returns the number of bits the bitmap was sized with.
+func (b *Bitmap) M() uint64 { return b.m }
+
+// K returns the hash-function count the bitmap was created with.
+func (b *Bitmap) K() uint64 { return b.k }
+
+// HashFamily returns the hash-family identifier the bitmap was created with.
+func (b *Bitmap) HashFamily() uint8 { return b.hashFamily }
+
+// MarshalBinary encodes the bitmap as magic bytes, a version byte, m, k, the hash-family id, the
+// raw bits, and a trailing CRC32 of the bits so UnmarshalBinary can detect a truncated payload.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	buf := bytes.NewBuffer(make([]byte, 0, bitmapHeaderLen+len(b.bits)+4))
+	buf.Write(bitmapMagic[:])
+	buf.WriteByte(bitmapVersion)
+	_ = binary.Write(buf, binary.BigEndian, b.m)
+	_ = binary.Write(buf, binary.BigEndian, b.k)
+	buf.WriteByte(b.hashFamily)
+	buf.Write(b.bits)
+	_ = binary.Write(buf, binary.BigEndian, crc32.ChecksumIEEE(b.bits))
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a snapshot produced by MarshalBinary, replacing the receiver's state.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < bitmapHeaderLen+4 {
+		return fmt.Errorf("bitmap: snapshot too short: %d bytes", len(data))
+	}
+	if !bytes.Equal(data[:4], bitmapMagic[:]) {
+		return fmt.Errorf("bitmap: snapshot has bad magic bytes")
+	}
+	if data[4] != bitmapVersion {
+		return fmt.Errorf("bitmap: unsupported snapshot version %d", data[4])
+	}
+
+	m := binary.BigEndian.Uint64(data[5:13])
+	k := binary.BigEndian.Uint64(data[13:21])
+	hashFamily := data[21]
+
+	bits := data[bitmapHeaderLen : len(data)-4]
+	wantCRC := binary.BigEndian.Uint32(data[len(data)-4:])
+	if gotCRC := crc32.ChecksumIEEE(bits); gotCRC != wantCRC {
+		return fmt.Errorf("bitmap: snapshot CRC mismatch: want %d, got %d", wantCRC, gotCRC)
+	}
+	if uint64(len(bits)) != (m+7)/8 {
+		return fmt.Errorf("bitmap: snapshot payload length %d doesn't match m=%d", len(bits), m)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.m = m
+	b.k = k
+	b.hashFamily = hashFamily
+	b.bits = append([]byte(nil), bits...)
+	return nil
+}
+
+var _ Backend = (*Bitmap)(nil)
@@ -0,0 +1,48 @@
+package bitmap
+
+import "testing"
+
+func TestBitmapMarshalUnmarshalRoundTrip(t *testing.T) {
+	b := NewBitmap(1000, 4, 1)
+	if err := b.SetBits([]uint64{1, 42, 999}); err != nil {
+		t.Fatalf("SetBits() error = %v", err)
+	}
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	restored := &Bitmap{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+
+	if restored.M() != 1000 || restored.K() != 4 || restored.HashFamily() != 1 {
+		t.Fatalf("restored metadata = (m=%d, k=%d, hashFamily=%d), want (1000, 4, 1)", restored.M(), restored.K(), restored.HashFamily())
+	}
+
+	exist, err := restored.CheckBits([]uint64{1, 42, 999})
+	if err != nil || !exist {
+		t.Fatalf("CheckBits() on restored bitmap = %v, %v, want true, nil", exist, err)
+	}
+	exist, err = restored.CheckBits([]uint64{2})
+	if err != nil || exist {
+		t.Fatalf("CheckBits() on restored bitmap for an unset bit = %v, %v, want false, nil", exist, err)
+	}
+}
+
+func TestBitmapUnmarshalBinaryRejectsCorruption(t *testing.T) {
+	b := NewBitmap(100, 2, 1)
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-1] ^= 0xFF
+
+	if err := (&Bitmap{}).UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary() on corrupted data returned nil error")
+	}
+}
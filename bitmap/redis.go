@@ -4,16 +4,67 @@ import (
 	"context"
 	"fmt"
 	"github.com/go-redis/redis/v8"
+	"io"
 	"time"
 )
 
 type RedisOption func(*Redis) error
 
+// RedisConfig describes how to connect to the Redis deployment backing the bitmap,
+// it is passed to NewRedisConfig and mirrors go-redis/v8's UniversalOptions so
+// callers can target a standalone instance, a Sentinel-managed instance, or a Cluster.
+type RedisConfig struct {
+	Addrs         []string
+	DB            int
+	MasterName    string
+	RouteRandomly bool
+}
+
+// toUniversalOptions converts RedisConfig into the go-redis UniversalOptions that decide
+// which client implementation (standalone/sentinel/cluster) go-redis constructs underneath.
+func (c RedisConfig) toUniversalOptions() *redis.UniversalOptions {
+	return &redis.UniversalOptions{
+		Addrs:         c.Addrs,
+		DB:            c.DB,
+		MasterName:    c.MasterName,
+		RouteRandomly: c.RouteRandomly,
+	}
+}
+
 type Redis struct {
-	ctx    context.Context
-	client *redis.Client
-	key    string
-	m      uint64
+	ctx            context.Context
+	client         redis.UniversalClient
+	key            string
+	m              uint64
+	checkAndSetSHA string
+}
+
+// checkAndSetScript iterates the given bit offsets, recording whether any of them was still 0,
+// sets all of them to 1, and returns that boolean so callers learn in one round-trip whether the
+// item was new. KEYS[1] is the bitmap key, ARGV holds the offsets.
+const checkAndSetScript = `
+local existed = 1
+for i = 1, #ARGV do
+	local bit = redis.call('GETBIT', KEYS[1], ARGV[i])
+	if bit == 0 then
+		existed = 0
+	end
+end
+for i = 1, #ARGV do
+	redis.call('SETBIT', KEYS[1], ARGV[i], 1)
+end
+return existed
+`
+
+// bitOffsets reduces each location in locs into the bitmap's range and converts it to the
+// []interface{} form EvalSha's variadic ARGV wants. It's split out from CheckAndSetBits so the
+// offset arithmetic can be unit tested without a Redis server.
+func bitOffsets(locs []uint64, m uint64) []interface{} {
+	offsets := make([]interface{}, len(locs))
+	for i, loc := range locs {
+		offsets[i] = int64(loc % m)
+	}
+	return offsets
 }
 
 func (r *Redis) CheckBits(locs []uint64) (bool, error) {
@@ -58,6 +109,105 @@ func (r *Redis) SetBits(locs []uint64) error {
 	return nil
 }
 
+// CheckAndSetBits atomically checks whether every offset in locs is already set and then sets
+// them all to 1, returning existed=true only if all of them were already 1. It replaces the
+// CheckBits+SetBits pair with a single EVALSHA round-trip, closing the race where a concurrent
+// Add could slip in between the two pipelines.
+func (r *Redis) CheckAndSetBits(locs []uint64) (existed bool, err error) {
+	res, err := r.client.EvalSha(r.ctx, r.checkAndSetSHA, []string{r.key}, bitOffsets(locs, r.m)...).Result()
+	if err != nil {
+		return false, err
+	}
+
+	existedVal, ok := res.(int64)
+	if !ok {
+		return false, fmt.Errorf("bitmap: unexpected CheckAndSetBits result type %T", res)
+	}
+	return existedVal == 1, nil
+}
+
+// redisDumpChunkSize is how many bytes of the bitmap string DumpTo/LoadFrom move per round-trip.
+// It keeps a single GETRANGE/SETRANGE well under Redis's proto-max-bulk-len even for large m.
+const redisDumpChunkSize = 512 * 1024
+
+// DumpTo streams the bitmap's raw string value to w in redisDumpChunkSize chunks via GETRANGE,
+// so a snapshot can be taken without ever holding the whole bitmap in memory at once.
+func (r *Redis) DumpTo(w io.Writer) error {
+	for start := int64(0); ; start += redisDumpChunkSize {
+		end := start + redisDumpChunkSize - 1
+		chunk, err := r.client.GetRange(r.ctx, r.key, start, end).Result()
+		if err != nil {
+			return err
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			return err
+		}
+		if int64(len(chunk)) < redisDumpChunkSize {
+			return nil
+		}
+	}
+}
+
+// LoadFrom reads a dump produced by DumpTo back into the bitmap's key in redisDumpChunkSize
+// pieces via SETRANGE, mirroring DumpTo's chunking so restoring a large bitmap never holds the
+// whole payload in memory or in a single Redis command. It verifies the restore landed intact by
+// comparing the resulting bit count against the number of set bits streamed in.
+func (r *Redis) LoadFrom(src io.Reader) error {
+	if err := r.client.Del(r.ctx, r.key).Err(); err != nil {
+		return err
+	}
+
+	buf := make([]byte, redisDumpChunkSize)
+	var offset int64
+	wantSet := 0
+	for {
+		n, readErr := io.ReadFull(src, buf)
+		if n > 0 {
+			if err := r.client.SetRange(r.ctx, r.key, offset, string(buf[:n])).Err(); err != nil {
+				return err
+			}
+			for _, b := range buf[:n] {
+				for b != 0 {
+					wantSet += int(b & 1)
+					b >>= 1
+				}
+			}
+			offset += int64(n)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	gotSet, err := r.client.BitCount(r.ctx, r.key, nil).Result()
+	if err != nil {
+		return err
+	}
+	if gotSet != int64(wantSet) {
+		return fmt.Errorf("bitmap: LoadFrom verification failed: want %d set bits, got %d", wantSet, gotSet)
+	}
+	return nil
+}
+
+// Close deletes the bitmap's key from Redis, releasing the space it held.
+func (r *Redis) Close() error {
+	return r.client.Del(r.ctx, r.key).Err()
+}
+
+// Reset clears every bit back to 0 without changing the bitmap's key.
+func (r *Redis) Reset() error {
+	if err := r.client.Del(r.ctx, r.key).Err(); err != nil {
+		return err
+	}
+	return r.client.SetBit(r.ctx, r.key, 0, 0).Err()
+}
+
 // RedisSetExpireTTL sets expiry TTL with d.
 func RedisSetExpireTTL(d time.Duration) RedisOption {
 	return func(r *Redis) error {
@@ -71,16 +221,35 @@ func RedisSetExpireTTL(d time.Duration) RedisOption {
 }
 
 // NewRedis returns bitmap that is store into redis and manipulated via github.com/go-redis/redis.
+// It keeps accepting *redis.Client for existing standalone callers; use NewRedisUniversal to target
+// Sentinel or Cluster deployments instead.
 func NewRedis(ctx context.Context, client *redis.Client, key string, m uint64, opts ...RedisOption) (*Redis, error) {
+	return NewRedisUniversal(ctx, client, key, m, opts...)
+}
+
+// NewRedisUniversal returns bitmap backed by any redis.UniversalClient, so callers can pass
+// *redis.Client, *redis.ClusterClient, or *redis.Ring depending on their deployment topology.
+// Every pipeline CheckBits/SetBits/CheckAndSetBits build only ever touches this one key, so on
+// Cluster there's no cross-slot command to worry about and no need for a {hash-tag} key rewrite.
+// Use NewRedisConfig to build client from RedisConfig when the caller doesn't already manage one.
+func NewRedisUniversal(ctx context.Context, client redis.UniversalClient, key string, m uint64, opts ...RedisOption) (*Redis, error) {
+	k := fmt.Sprintf("%s_%d", key, time.Now().UnixNano())
+
 	r := &Redis{
 		ctx:    ctx,
 		client: client,
-		key:    fmt.Sprintf("%s_%d", key, time.Now().UnixNano()),
+		key:    k,
 		m:      m,
 	}
 	// Set the empty bitmap with the key in Redis to avoid subsequent Redis operations might be ineffective such as expiry setting.
 	r.client.SetBit(r.ctx, r.key, 0, 0)
 
+	sha, err := redis.NewScript(checkAndSetScript).Load(r.ctx, r.client).Result()
+	if err != nil {
+		return nil, err
+	}
+	r.checkAndSetSHA = sha
+
 	for _, opt := range opts {
 		err := opt(r)
 		if err != nil {
@@ -90,3 +259,15 @@ func NewRedis(ctx context.Context, client *redis.Client, key string, m uint64, o
 
 	return r, nil
 }
+
+// NewRedisConfig builds a redis.UniversalClient from cfg (picking standalone, Sentinel, or
+// Cluster mode the same way go-redis's NewUniversalClient does) and returns the bitmap backed by it.
+func NewRedisConfig(ctx context.Context, cfg RedisConfig, key string, m uint64, opts ...RedisOption) (*Redis, error) {
+	client := redis.NewUniversalClient(cfg.toUniversalOptions())
+	return NewRedisUniversal(ctx, client, key, m, opts...)
+}
+
+var (
+	_ Backend       = (*Redis)(nil)
+	_ AtomicBackend = (*Redis)(nil)
+)
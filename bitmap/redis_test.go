@@ -0,0 +1,43 @@
+package bitmap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBitOffsets(t *testing.T) {
+	cases := []struct {
+		name string
+		locs []uint64
+		m    uint64
+		want []interface{}
+	}{
+		{
+			name: "within range",
+			locs: []uint64{0, 1, 7},
+			m:    100,
+			want: []interface{}{int64(0), int64(1), int64(7)},
+		},
+		{
+			name: "wraps around m",
+			locs: []uint64{100, 150},
+			m:    100,
+			want: []interface{}{int64(0), int64(50)},
+		},
+		{
+			name: "empty",
+			locs: nil,
+			m:    100,
+			want: []interface{}{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := bitOffsets(c.locs, c.m)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("bitOffsets(%v, %d) = %v, want %v", c.locs, c.m, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"fmt"
+	"github.com/x0rworld/go-bloomfilter/bitmap"
+	"hash/fnv"
+	"io"
+)
+
+// Bloom is the classic bit-array bloom filter: Add sets every hashed location, Exist reports
+// whether all of them are already set. It never forgets an item on its own; Rotator supplies
+// that by swapping/aging the underlying bitmap.
+type Bloom struct {
+	backend bitmap.Backend
+	locs    HashFunc
+}
+
+// NewBloom returns a Bloom filter that tests/sets the locations locs computes against backend.
+func NewBloom(backend bitmap.Backend, locs HashFunc) *Bloom {
+	return &Bloom{backend: backend, locs: locs}
+}
+
+func (f *Bloom) Add(data string) error {
+	return f.backend.SetBits(f.locs(data))
+}
+
+func (f *Bloom) Exist(data string) (bool, error) {
+	return f.backend.CheckBits(f.locs(data))
+}
+
+// AddIfNotExist atomically checks and sets data's locations in a single round-trip, returning
+// existed=true if every location was already set (i.e. data was already a member), without the
+// race a separate Exist+Add pair would have. It is a method of *Bloom only: neither filter.Filter
+// nor rotator.Rotator exposes it. It requires backend to implement bitmap.AtomicBackend;
+// backends without an atomic primitive (e.g. Memcached) return an error instead of silently
+// falling back to the racy pair.
+func (f *Bloom) AddIfNotExist(data string) (existed bool, err error) {
+	atomicBackend, ok := f.backend.(bitmap.AtomicBackend)
+	if !ok {
+		return false, fmt.Errorf("filter: backend %T does not support AddIfNotExist", f.backend)
+	}
+	return atomicBackend.CheckAndSetBits(f.locs(data))
+}
+
+// Snapshot writes the filter's bitmap to w via bitmap.Bitmap's own binary encoding, so
+// LoadSnapshot can rebuild an equivalent Bloom later without the caller supplying m/k/the hash
+// function by hand. It only supports an in-memory *bitmap.Bitmap backend today; Redis
+// snapshotting goes through bitmap.Redis.DumpTo/LoadFrom instead, since the data already lives
+// server-side and doesn't need to round-trip through the process.
+func (f *Bloom) Snapshot(w io.Writer) error {
+	mb, ok := f.backend.(*bitmap.Bitmap)
+	if !ok {
+		return fmt.Errorf("filter: Snapshot only supports an in-memory bitmap.Bitmap backend, got %T", f.backend)
+	}
+	data, err := mb.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// LoadSnapshot rebuilds a Bloom filter from a snapshot written by Snapshot, reconstructing its
+// hash function from the hash-family id embedded in the snapshot's header.
+func LoadSnapshot(r io.Reader) (Filter, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mb := &bitmap.Bitmap{}
+	if err := mb.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	locs, err := NewHashFunc(mb.HashFamily(), mb.M(), mb.K())
+	if err != nil {
+		return nil, err
+	}
+	return NewBloom(mb, locs), nil
+}
+
+// HashFamilyFNV identifies the double-hashing FNV-based HashFunc built by NewHashFunc; it's the
+// only family this package knows how to build today, but the id is persisted in every snapshot
+// so a future family can be added without breaking old ones.
+const HashFamilyFNV uint8 = 1
+
+// NewHashFunc returns the HashFunc for the given hash family, m, and k, or an error if the
+// family is unknown. LoadSnapshot uses it to reconstruct a filter's hash function purely from
+// the metadata embedded in a snapshot.
+func NewHashFunc(hashFamily uint8, m, k uint64) (HashFunc, error) {
+	switch hashFamily {
+	case HashFamilyFNV:
+		return fnvHashFunc(m, k), nil
+	default:
+		return nil, fmt.Errorf("filter: unknown hash family %d", hashFamily)
+	}
+}
+
+// fnvHashFunc implements the standard double-hashing scheme (Kirsch-Mitzenmacher): two
+// independent FNV hashes of data are combined as sum1+i*sum2 to derive k locations without
+// running k separate hash functions.
+func fnvHashFunc(m, k uint64) HashFunc {
+	return func(data string) []uint64 {
+		h1 := fnv.New64a()
+		_, _ = h1.Write([]byte(data))
+		sum1 := h1.Sum64()
+
+		h2 := fnv.New64()
+		_, _ = h2.Write([]byte(data))
+		sum2 := h2.Sum64()
+
+		locs := make([]uint64, k)
+		for i := uint64(0); i < k; i++ {
+			locs[i] = (sum1 + i*sum2) % m
+		}
+		return locs
+	}
+}
+
+var _ Filter = (*Bloom)(nil)
@@ -0,0 +1,38 @@
+package filter
+
+import (
+	"bytes"
+	"github.com/x0rworld/go-bloomfilter/bitmap"
+	"testing"
+)
+
+func TestBloomSnapshotLoadSnapshotRoundTrip(t *testing.T) {
+	locs, err := NewHashFunc(HashFamilyFNV, 1000, 4)
+	if err != nil {
+		t.Fatalf("NewHashFunc() error = %v", err)
+	}
+
+	f := NewBloom(bitmap.NewBitmap(1000, 4, HashFamilyFNV), locs)
+	if err := f.Add("hello"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	restored, err := LoadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+
+	exist, err := restored.Exist("hello")
+	if err != nil || !exist {
+		t.Fatalf("Exist(\"hello\") on restored filter = %v, %v, want true, nil", exist, err)
+	}
+	exist, err = restored.Exist("world")
+	if err != nil || exist {
+		t.Fatalf("Exist(\"world\") on restored filter = %v, %v, want false, nil", exist, err)
+	}
+}
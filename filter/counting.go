@@ -0,0 +1,71 @@
+package filter
+
+import "math/rand"
+
+// CounterBackend is the subset of a counting bitmap (bitmap.RedisCounter, bitmap.MemoryCounter)
+// that Counting needs: CheckBits/SetBits from bitmap.Backend, DecBits to actually evict a single
+// item instead of swapping the whole filter, and M so DecrementRandom can pick slots to decay
+// without knowing the backend's internal layout.
+type CounterBackend interface {
+	CheckBits(locs []uint64) (bool, error)
+	SetBits(locs []uint64) error
+	DecBits(locs []uint64) error
+	M() uint64
+}
+
+// defaultDecayCount is how many random slots DecrementRandom decays per call when decayCount is
+// unset (0), mirroring the "0 means use a sane default" convention Cuckoo's maxKicks already
+// uses.
+const defaultDecayCount = 1
+
+// Counting is a counting-bloom filter: like Bloom, but backed by a CounterBackend so Remove can
+// decrement a single item's counters instead of requiring the whole filter to be swapped out.
+type Counting struct {
+	backend    CounterBackend
+	locs       HashFunc
+	decayCount int
+}
+
+// NewCounting returns a Counting filter that tests/sets/clears the locations locs computes
+// against backend. decayCount is how many random slots DecrementRandom decays per call (see
+// DecrementRandom); 0 uses defaultDecayCount.
+func NewCounting(backend CounterBackend, locs HashFunc, decayCount int) *Counting {
+	if decayCount <= 0 {
+		decayCount = defaultDecayCount
+	}
+	return &Counting{backend: backend, locs: locs, decayCount: decayCount}
+}
+
+func (f *Counting) Add(data string) error {
+	return f.backend.SetBits(f.locs(data))
+}
+
+func (f *Counting) Exist(data string) (bool, error) {
+	return f.backend.CheckBits(f.locs(data))
+}
+
+// Remove decrements data's counters by 1, evicting it once they reach 0. A counter that is
+// already 0 stays 0, since CounterBackend floors decrements at 0; a counter shared with other
+// items is decremented whether or not data was ever added.
+func (f *Counting) Remove(data string) error {
+	return f.backend.DecBits(f.locs(data))
+}
+
+// DecrementRandom decays f.decayCount random slots by 1, satisfying rotator's stableDecrementer
+// interface so Counting can back a Stable Bloom Filter: every Add pairs with a decay of this
+// size, bounding the false-positive rate over an unbounded stream instead of requiring periodic
+// rotation.
+func (f *Counting) DecrementRandom() error {
+	m := f.backend.M()
+	if m == 0 {
+		return nil
+	}
+
+	locs := make([]uint64, f.decayCount)
+	for i := range locs {
+		locs[i] = uint64(rand.Int63n(int64(m)))
+	}
+	return f.backend.DecBits(locs)
+}
+
+var _ Filter = (*Counting)(nil)
@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/x0rworld/go-bloomfilter/bitmap"
+)
+
+func TestCountingDecrementRandomDecaysAddedItem(t *testing.T) {
+	backend, err := bitmap.NewMemoryCounter(1)
+	if err != nil {
+		t.Fatalf("NewMemoryCounter() error = %v", err)
+	}
+	locs := func(data string) []uint64 { return []uint64{0} }
+	f := NewCounting(backend, locs, 1)
+
+	if err := f.Add("x"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	exist, err := f.Exist("x")
+	if err != nil || !exist {
+		t.Fatalf("Exist() = %v, %v after Add, want true, nil", exist, err)
+	}
+
+	if err := f.DecrementRandom(); err != nil {
+		t.Fatalf("DecrementRandom() error = %v", err)
+	}
+	exist, err = f.Exist("x")
+	if err != nil || exist {
+		t.Fatalf("Exist() = %v, %v after DecrementRandom, want false, nil", exist, err)
+	}
+}
+
+func TestCountingDecrementRandomOnEmptyBackendIsNoop(t *testing.T) {
+	backend, err := bitmap.NewMemoryCounter(0)
+	if err != nil {
+		t.Fatalf("NewMemoryCounter() error = %v", err)
+	}
+	f := NewCounting(backend, func(string) []uint64 { return nil }, 0)
+
+	if err := f.DecrementRandom(); err != nil {
+		t.Fatalf("DecrementRandom() on a 0-slot backend error = %v", err)
+	}
+}
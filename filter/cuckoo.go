@@ -0,0 +1,152 @@
+package filter
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+)
+
+// errCuckooFull is returned by Add when maxKicks relocations couldn't find a free slot for an
+// item's fingerprint, meaning the filter is too full (or unlucky) to accept it.
+var errCuckooFull = errors.New("filter: cuckoo filter is full")
+
+// errInvalidNumBuckets is returned by NewCuckoo for a non-positive numBuckets, which would
+// otherwise divide by zero in locate/altIndex on the first Add/Exist call.
+var errInvalidNumBuckets = errors.New("filter: numBuckets must be positive")
+
+// cuckooBucketSize is how many fingerprints each bucket holds before Add has to kick one out to
+// make room, trading a bit of extra space for a much lower false-positive rate than 1 slot/bucket.
+const cuckooBucketSize = 4
+
+// defaultMaxKicks bounds how many times Add relocates a fingerprint between its two candidate
+// buckets before giving up and reporting the filter as full.
+const defaultMaxKicks = 500
+
+type cuckooBucket [cuckooBucketSize]uint8
+
+// indexOf returns the slot holding fp, or -1 if the bucket doesn't contain it.
+func (b cuckooBucket) indexOf(fp uint8) int {
+	for i, v := range b {
+		if v == fp {
+			return i
+		}
+	}
+	return -1
+}
+
+// Cuckoo is a cuckoo filter: each item is reduced to a fingerprint stored in one of two
+// candidate buckets, so unlike Bloom/Counting it can delete a single item by simply clearing its
+// fingerprint, without a counter or a full filter swap.
+type Cuckoo struct {
+	mu       sync.Mutex
+	buckets  []cuckooBucket
+	maxKicks int
+}
+
+// NewCuckoo returns a Cuckoo filter with numBuckets buckets, rounded up to the next power of two
+// so that altIndex is its own inverse. maxKicks bounds Add's relocation loop; 0 uses
+// defaultMaxKicks. numBuckets must be positive, unlike maxKicks there's no sane default to clamp
+// it to.
+func NewCuckoo(numBuckets int, maxKicks int) (*Cuckoo, error) {
+	if numBuckets <= 0 {
+		return nil, errInvalidNumBuckets
+	}
+	if maxKicks <= 0 {
+		maxKicks = defaultMaxKicks
+	}
+	n := 1
+	for n < numBuckets {
+		n <<= 1
+	}
+	return &Cuckoo{
+		buckets:  make([]cuckooBucket, n),
+		maxKicks: maxKicks,
+	}, nil
+}
+
+// locate hashes data into its two candidate bucket indices and its fingerprint. The second
+// index is derived by XORing the first index with a hash of the fingerprint, so Add can recover
+// it later from just the fingerprint and either bucket (the standard cuckoo-filter trick).
+func (f *Cuckoo) locate(data string) (i1, i2 int, fp uint8) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(data))
+	sum := h.Sum64()
+
+	n := len(f.buckets)
+	i1 = int(sum % uint64(n))
+	fp = uint8(sum>>32) | 1 // never 0: 0 marks an empty slot
+
+	i2 = f.altIndex(i1, fp)
+	return i1, i2, fp
+}
+
+// altIndex returns the other candidate bucket for fingerprint fp given one of its bucket
+// indices, by XORing in a hash of fp. Calling it twice with the two indices of the same (i, fp)
+// pair returns the other one, which is what lets Add relocate a kicked-out fingerprint.
+func (f *Cuckoo) altIndex(i int, fp uint8) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte{fp})
+	return (i ^ int(h.Sum32())) % len(f.buckets)
+}
+
+func (f *Cuckoo) Exist(data string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i1, i2, fp := f.locate(data)
+	return f.buckets[i1].indexOf(fp) >= 0 || f.buckets[i2].indexOf(fp) >= 0, nil
+}
+
+func (f *Cuckoo) Add(data string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i1, i2, fp := f.locate(data)
+	if f.insertInto(i1, fp) || f.insertInto(i2, fp) {
+		return nil
+	}
+
+	// Both candidate buckets are full: evict a random occupant from i1 and relocate it to its
+	// other candidate bucket, repeating until something finds a free slot or maxKicks runs out.
+	i := i1
+	for kick := 0; kick < f.maxKicks; kick++ {
+		slot := rand.Intn(cuckooBucketSize)
+		fp, f.buckets[i][slot] = f.buckets[i][slot], fp
+		i = f.altIndex(i, fp)
+		if f.insertInto(i, fp) {
+			return nil
+		}
+	}
+	return errCuckooFull
+}
+
+// insertInto places fp in bucket i's first empty slot, reporting whether it found one.
+func (f *Cuckoo) insertInto(i int, fp uint8) bool {
+	for slot, v := range f.buckets[i] {
+		if v == 0 {
+			f.buckets[i][slot] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// Remove clears data's fingerprint from whichever of its two candidate buckets holds it. It's a
+// no-op if data was never added.
+func (f *Cuckoo) Remove(data string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i1, i2, fp := f.locate(data)
+	if slot := f.buckets[i1].indexOf(fp); slot >= 0 {
+		f.buckets[i1][slot] = 0
+		return nil
+	}
+	if slot := f.buckets[i2].indexOf(fp); slot >= 0 {
+		f.buckets[i2][slot] = 0
+	}
+	return nil
+}
+
+var _ Filter = (*Cuckoo)(nil)
@@ -0,0 +1,49 @@
+package filter
+
+import "testing"
+
+func TestCuckooAddExistRemove(t *testing.T) {
+	f, err := NewCuckoo(64, 0)
+	if err != nil {
+		t.Fatalf("NewCuckoo() error = %v", err)
+	}
+
+	exist, err := f.Exist("a")
+	if err != nil || exist {
+		t.Fatalf("Exist() = %v, %v before Add, want false, nil", exist, err)
+	}
+
+	if err := f.Add("a"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	exist, err = f.Exist("a")
+	if err != nil || !exist {
+		t.Fatalf("Exist() = %v, %v after Add, want true, nil", exist, err)
+	}
+
+	if err := f.Remove("a"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	exist, err = f.Exist("a")
+	if err != nil || exist {
+		t.Fatalf("Exist() = %v, %v after Remove, want false, nil", exist, err)
+	}
+}
+
+func TestCuckooRemoveMissingIsNoop(t *testing.T) {
+	f, err := NewCuckoo(64, 0)
+	if err != nil {
+		t.Fatalf("NewCuckoo() error = %v", err)
+	}
+	if err := f.Remove("never-added"); err != nil {
+		t.Fatalf("Remove() on a missing item error = %v", err)
+	}
+}
+
+func TestNewCuckooRejectsNonPositiveNumBuckets(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if _, err := NewCuckoo(n, 0); err == nil {
+			t.Errorf("NewCuckoo(%d, 0) error = nil, want errInvalidNumBuckets", n)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Package filter implements the bloom-filter variants (classic, counting, cuckoo) that sit on
+// top of a bitmap.Backend and turn raw bit locations into membership tests.
+//
+// Counting and Cuckoo are reached by constructing them directly (NewCounting, NewCuckoo); there
+// is no factory or config package in this tree, so no config field selects a filter variant.
+package filter
+
+// Filter is implemented by every bloom-filter variant that rotator.Rotator manages. Add records
+// data as a member; Exist reports whether it (probably) already is.
+type Filter interface {
+	Add(data string) error
+	Exist(data string) (bool, error)
+}
+
+// HashFunc maps data to the k bit/slot locations a Filter tests and sets for it.
+type HashFunc func(data string) []uint64
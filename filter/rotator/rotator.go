@@ -2,10 +2,12 @@
 package rotator
 
 import (
+	"bytes"
 	"context"
-	"github.com/x0rworld/go-bloomfilter/config"
+	"fmt"
 	"github.com/x0rworld/go-bloomfilter/core"
 	"github.com/x0rworld/go-bloomfilter/filter"
+	"io"
 	"sync/atomic"
 	"time"
 )
@@ -14,20 +16,88 @@ import (
 // It's the same signature with factory.FilterFactory.NewFilter.
 type NewFilterFunc func(ctx context.Context) (filter.Filter, error)
 
+// ModeSliding keeps a ring of RotatorConfig.Windows generations and answers Exist by
+// checking all of them, instead of hard-swapping to a single "current" filter at each period.
+const ModeSliding = "sliding"
+
+// ModeStable implements a Stable Bloom Filter variant: every Add decrements random counters (via
+// DecrementRandom) and then adds the item through the filter's own Add, which for
+// filter.Counting increments its counters by 1; the counters are not set to bitmap.CounterMax.
+// There's no periodic rotation at all. It requires a filter.Filter that implements stableDecrementer (a counting filter that can
+// decay itself, e.g. filter.Counting.DecrementRandom); NewRotator rejects any other filter up
+// front rather than silently running without decay.
+const ModeStable = "stable"
+
+// defaultWindows is used when RotatorConfig.Windows is unset (zero value) so existing
+// callers of the classic two-filter rotation keep their current behavior.
+const defaultWindows = 2
+
+// stableDecrementer is implemented by filter.Filter values that back onto a counting bitmap
+// (e.g. filter.Counting) and can therefore decay counters in place instead of rotating. Rotator
+// only needs this for ModeStable, so it's kept as a local, minimal interface rather than widening
+// filter.Filter for every backend.
+type stableDecrementer interface {
+	DecrementRandom() error
+}
+
+// snapshotName is the key Rotator saves/loads the "current" filter's snapshot under in
+// cfg.SnapshotStore. Only one name is needed: the next filter always starts empty, so there's
+// nothing worth persisting for it.
+const snapshotName = "current"
+
+// snapshotter is implemented by filter.Filter values that can serialize themselves (e.g.
+// filter.Bloom). Rotator only rehydrates/flushes the default (classic) mode's current filter,
+// so this stays a local, minimal interface rather than widening filter.Filter for every backend.
+type snapshotter interface {
+	Snapshot(w io.Writer) error
+}
+
+// SnapshotStore persists and retrieves a named filter snapshot for Rotator's warm-start
+// (rehydrate/flushSnapshot). It's defined locally, the same way stableDecrementer and snapshotter
+// are, so Rotator doesn't depend on a package this tree doesn't have; FileSnapshotStore is the
+// reference implementation, and callers with a shared filesystem can use it as-is.
+type SnapshotStore interface {
+	Save(ctx context.Context, name string, data []byte) error
+	Load(ctx context.Context, name string) ([]byte, error)
+}
+
+// RotatorConfig configures a Rotator. Mode selects the rotation strategy (ModeSliding,
+// ModeStable, or "" for the classic two-filter swap); Windows only applies to ModeSliding
+// (defaultWindows if unset); SnapshotStore only applies to the classic mode's warm-start.
+type RotatorConfig struct {
+	Enable        bool
+	Mode          string
+	Windows       int
+	Freq          time.Duration
+	SnapshotStore SnapshotStore
+}
+
 type filterPair struct {
 	current filter.Filter
 	next    filter.Filter
 }
 
+// slidingWindow holds the last len(gens) generations ordered oldest-to-newest; gens[len-1] is
+// the generation that Add writes to, and Exist is true if any generation contains the item.
+type slidingWindow struct {
+	gens []filter.Filter
+}
+
 type Rotator struct {
 	ctx       context.Context
-	cfg       config.RotatorConfig
+	cfg       RotatorConfig
 	newFilter NewFilterFunc
-	// type: *filterPair
+	// type: *filterPair (default mode) or *slidingWindow (ModeSliding) or filter.Filter (ModeStable)
 	pair atomic.Value
 }
 
 func (r *Rotator) handleRotating(freq time.Duration) {
+	// Stable Bloom Filters decay on every Add instead of on a period boundary, so there's
+	// nothing for the timer loop to do.
+	if r.cfg.Mode == ModeStable {
+		return
+	}
+
 	for {
 		current := time.Now()
 		next := current.Add(freq).Truncate(freq)
@@ -36,17 +106,61 @@ func (r *Rotator) handleRotating(freq time.Duration) {
 		case <-timer.C:
 			r.rotate()
 		case <-r.ctx.Done():
+			r.flushSnapshot()
 			return
 		}
 	}
 }
 
+// flushSnapshot persists the default mode's current filter to cfg.SnapshotStore (if configured)
+// so the next process can rehydrate() instead of starting cold.
+func (r *Rotator) flushSnapshot() {
+	if r.cfg.SnapshotStore == nil || r.cfg.Mode == ModeSliding || r.cfg.Mode == ModeStable {
+		return
+	}
+
+	f, ok := r.pair.Load().(*filterPair).current.(snapshotter)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := f.Snapshot(&buf); err != nil {
+		return
+	}
+	_ = r.cfg.SnapshotStore.Save(r.ctx, snapshotName, buf.Bytes())
+}
+
+// rehydrate loads a previously flushed snapshot (if any) and rebuilds the default mode's
+// current filter from it, so Rotator doesn't start every process cold.
+func (r *Rotator) rehydrate() (filter.Filter, error) {
+	if r.cfg.SnapshotStore == nil {
+		return nil, nil
+	}
+
+	data, err := r.cfg.SnapshotStore.Load(r.ctx, snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return filter.LoadSnapshot(bytes.NewReader(data))
+}
+
 func (r *Rotator) rotate() error {
 	newFilter, err := r.genFilter(true)
 	if err != nil {
 		return err
 	}
 
+	if r.cfg.Mode == ModeSliding {
+		oldWindow := r.pair.Load().(*slidingWindow)
+		gens := append(append([]filter.Filter{}, oldWindow.gens[1:]...), newFilter)
+		r.pair.Store(&slidingWindow{gens: gens})
+		return nil
+	}
+
 	oldPair := r.pair.Load().(*filterPair)
 	newPair := &filterPair{
 		current: oldPair.next,
@@ -57,16 +171,46 @@ func (r *Rotator) rotate() error {
 }
 
 func (r *Rotator) Exist(data string) (bool, error) {
-	return r.pair.Load().(*filterPair).current.Exist(data)
+	switch r.cfg.Mode {
+	case ModeSliding:
+		for _, g := range r.pair.Load().(*slidingWindow).gens {
+			exist, err := g.Exist(data)
+			if err != nil {
+				return false, err
+			}
+			if exist {
+				return true, nil
+			}
+		}
+		return false, nil
+	case ModeStable:
+		return r.pair.Load().(filter.Filter).Exist(data)
+	default:
+		return r.pair.Load().(*filterPair).current.Exist(data)
+	}
 }
 
 func (r *Rotator) Add(data string) error {
-	p := r.pair.Load().(*filterPair)
-	err := p.current.Add(data)
-	if err != nil {
-		return err
+	switch r.cfg.Mode {
+	case ModeSliding:
+		gens := r.pair.Load().(*slidingWindow).gens
+		return gens[len(gens)-1].Add(data)
+	case ModeStable:
+		f := r.pair.Load().(filter.Filter)
+		// NewRotator already rejected any filter that doesn't implement stableDecrementer,
+		// so this assertion can't fail here.
+		if err := f.(stableDecrementer).DecrementRandom(); err != nil {
+			return err
+		}
+		return f.Add(data)
+	default:
+		p := r.pair.Load().(*filterPair)
+		err := p.current.Add(data)
+		if err != nil {
+			return err
+		}
+		return p.next.Add(data)
 	}
-	return p.next.Add(data)
 }
 
 func (r *Rotator) genFilter(isNext bool) (filter.Filter, error) {
@@ -86,10 +230,17 @@ func (r *Rotator) genFilter(isNext bool) (filter.Filter, error) {
 }
 
 func (r *Rotator) genFilterPair() (*filterPair, error) {
-	current, err := r.genFilter(false)
+	current, err := r.rehydrate()
 	if err != nil {
 		return nil, err
 	}
+	if current == nil {
+		current, err = r.genFilter(false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	next, err := r.genFilter(true)
 	if err != nil {
 		return nil, err
@@ -100,19 +251,56 @@ func (r *Rotator) genFilterPair() (*filterPair, error) {
 	}, nil
 }
 
+// genSlidingWindow fills a fresh ring of cfg.Windows generations (defaultWindows if unset),
+// all newly created since there's no history to seed them with at start-up.
+func (r *Rotator) genSlidingWindow() (*slidingWindow, error) {
+	windows := r.cfg.Windows
+	if windows <= 0 {
+		windows = defaultWindows
+	}
+
+	gens := make([]filter.Filter, windows)
+	for i := range gens {
+		f, err := r.genFilter(i == windows-1)
+		if err != nil {
+			return nil, err
+		}
+		gens[i] = f
+	}
+	return &slidingWindow{gens: gens}, nil
+}
+
 // NewRotator returns *Rotator that rotates filter by period, all rotating filters will be generated by newFilter.
-func NewRotator(ctx context.Context, cfg config.RotatorConfig, newFilter NewFilterFunc) (*Rotator, error) {
+func NewRotator(ctx context.Context, cfg RotatorConfig, newFilter NewFilterFunc) (*Rotator, error) {
 	r := &Rotator{
 		ctx:       ctx,
 		cfg:       cfg,
 		newFilter: newFilter,
 	}
 
-	p, err := r.genFilterPair()
-	if err != nil {
-		return nil, err
+	switch cfg.Mode {
+	case ModeSliding:
+		w, err := r.genSlidingWindow()
+		if err != nil {
+			return nil, err
+		}
+		r.pair.Store(w)
+	case ModeStable:
+		f, err := r.genFilter(false)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := f.(stableDecrementer); !ok {
+			return nil, fmt.Errorf("rotator: ModeStable requires a filter.Filter that implements DecrementRandom() error, got %T", f)
+		}
+		r.pair.Store(f)
+	default:
+		p, err := r.genFilterPair()
+		if err != nil {
+			return nil, err
+		}
+		r.pair.Store(p)
 	}
-	r.pair.Store(p)
 
 	go r.handleRotating(cfg.Freq)
 
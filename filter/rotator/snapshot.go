@@ -0,0 +1,53 @@
+package rotator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// FileSnapshotStore implements SnapshotStore by writing each snapshot to its own file under
+// Dir, named after the snapshot's name. It's the reference implementation shipped alongside
+// Rotator; callers with a shared filesystem (NFS, a sidecar volume) can use it as-is, everyone
+// else implements SnapshotStore against S3, Redis, etc.
+type FileSnapshotStore struct {
+	Dir string
+}
+
+// NewFileSnapshotStore returns a FileSnapshotStore rooted at dir, creating dir if it doesn't
+// already exist.
+func NewFileSnapshotStore(dir string) (*FileSnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSnapshotStore{Dir: dir}, nil
+}
+
+func (s *FileSnapshotStore) path(name string) string {
+	return filepath.Join(s.Dir, name+".snapshot")
+}
+
+// Save writes data to name's snapshot file, replacing it atomically via a temp file + rename so
+// a crash mid-write can't leave a half-written snapshot for the next Load to trip over.
+func (s *FileSnapshotStore) Save(ctx context.Context, name string, data []byte) error {
+	tmp := s.path(name) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(name))
+}
+
+// Load reads name's snapshot file, returning (nil, nil) if it doesn't exist yet so callers can
+// tell "no snapshot taken" apart from a real I/O error.
+func (s *FileSnapshotStore) Load(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+var _ SnapshotStore = (*FileSnapshotStore)(nil)